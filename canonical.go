@@ -0,0 +1,246 @@
+package gohash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// canonicalFormatVersion is the first byte written by [FromCanonical]. Bump
+// this whenever the wire format below changes in an incompatible way.
+const canonicalFormatVersion byte = 1
+
+// canonicalTag identifies the shape of the value that follows in the
+// canonical encoding. Unlike [reflect.Kind], these values are defined by this
+// package and will never change meaning across Go versions.
+type canonicalTag byte
+
+const (
+	canonicalTagNil canonicalTag = iota + 1
+	canonicalTagBool
+	canonicalTagInt
+	canonicalTagUint
+	canonicalTagFloat32
+	canonicalTagFloat64
+	canonicalTagString
+	canonicalTagBytes
+	canonicalTagSlice
+	canonicalTagMap
+	canonicalTagStruct
+)
+
+// CanonicalOptions configures [FromCanonical].
+type CanonicalOptions struct {
+	// TypeName, when set, is called for every struct type encountered and its
+	// result is written as that struct's identity. When nil, "pkg.TypeName"
+	// (the value of [reflect.Type.String]) is used instead.
+	//
+	// Set this if the default identity is not stable enough for your use case,
+	// for example because you rename or move struct types between releases.
+	TypeName func(t reflect.Type) string
+}
+
+// FromCanonical hashes input using a versioned, self-describing wire format
+// that is stable across Go versions and package moves: a 1-byte format
+// version, fixed type tags defined by this package, uvarint length-prefixed
+// strings and byte slices, big-endian fixed-width integers, and IEEE-754 raw
+// bits for floats (so two NaNs with identical bits hash equal). Unlike [From],
+// whose output depends on reflect.Kind values and Go's native type
+// formatting, hashes produced by FromCanonical are safe to persist on disk or
+// compare across processes running different Go versions.
+func FromCanonical(input any, hasher hash.Hash, opts *CanonicalOptions) (Hash, error) {
+	if opts == nil {
+		opts = &CanonicalOptions{}
+	}
+
+	hasher.Write([]byte{canonicalFormatVersion})
+
+	visited := &visitStack{}
+	if err := walkCanonical(input, hasher, 0, visited, opts); err != nil {
+		return nil, fmt.Errorf("fromcanonical: %w", err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// walkCanonical is the canonical-format counterpart to [walkObject].
+func walkCanonical(input any, hasher hash.Hash, depth int, visited *visitStack, opts *CanonicalOptions) error {
+	if depth > 100 {
+		return fmt.Errorf("depth exceeded for type %T", input)
+	}
+
+	rv := reflect.ValueOf(input)
+	kind := rv.Kind()
+
+	if kind == reflect.Ptr || kind == reflect.Map ||
+		kind == reflect.Slice || kind == reflect.Interface {
+		if rv.IsNil() {
+			hasher.Write([]byte{byte(canonicalTagNil)})
+			return nil
+		}
+
+		// A back-reference, not a plain "already seen" skip: this distinguishes a
+		// genuine cycle from two independent occurrences of the same address.
+		backDepth, ok := visited.push(rv.UnsafePointer(), rv.Type())
+		if !ok {
+			hasher.Write([]byte{byte(backReferenceTag)})
+			writeCanonicalUvarint(hasher, uint64(backDepth))
+			return nil
+		}
+		defer visited.pop()
+	}
+
+	// Give values a chance to describe their own canonical identity before we
+	// dereference pointers, so that pointer-receiver implementations are
+	// honored, same as [walkObject]. This lets types such as time.Time or
+	// big.Int hash by their semantic value instead of their private field
+	// layout, even in the persisted canonical format.
+	if ok, err := writeCustomHash(input, hasher); ok || err != nil {
+		return err
+	}
+
+	pDepth := 0
+	for {
+		if kind != reflect.Pointer && kind != reflect.Interface {
+			break
+		}
+
+		pDepth++
+		if pDepth >= 100 {
+			return fmt.Errorf("input '%v' of type %T has too many pointers", input, input)
+		}
+
+		rv = rv.Elem()
+		kind = rv.Kind()
+	}
+
+	// The value may only have revealed itself to implement a custom hashing
+	// hook once indirection through a pointer or interface was peeled off
+	// above, for example a *any holding a time.Time.
+	if rv.IsValid() {
+		if ok, err := writeCustomHash(rv.Interface(), hasher); ok || err != nil {
+			return err
+		}
+	}
+
+	switch kind {
+	case reflect.Invalid:
+		hasher.Write([]byte{byte(canonicalTagNil)})
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var buf [9]byte
+		buf[0] = byte(canonicalTagInt)
+		binary.BigEndian.PutUint64(buf[1:], uint64(rv.Int()))
+		hasher.Write(buf[:])
+		return nil
+
+	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var buf [9]byte
+		buf[0] = byte(canonicalTagUint)
+		binary.BigEndian.PutUint64(buf[1:], rv.Uint())
+		hasher.Write(buf[:])
+		return nil
+
+	case reflect.Float32:
+		var buf [5]byte
+		buf[0] = byte(canonicalTagFloat32)
+		binary.BigEndian.PutUint32(buf[1:], math.Float32bits(float32(rv.Float())))
+		hasher.Write(buf[:])
+		return nil
+
+	case reflect.Float64:
+		var buf [9]byte
+		buf[0] = byte(canonicalTagFloat64)
+		binary.BigEndian.PutUint64(buf[1:], math.Float64bits(rv.Float()))
+		hasher.Write(buf[:])
+		return nil
+
+	case reflect.Bool:
+		b := byte(0)
+		if rv.Bool() {
+			b = 1
+		}
+		hasher.Write([]byte{byte(canonicalTagBool), b})
+		return nil
+
+	case reflect.String:
+		hasher.Write([]byte{byte(canonicalTagString)})
+		writeCanonicalBytes(hasher, []byte(rv.String()))
+		return nil
+
+	case reflect.Array, reflect.Slice:
+		hasher.Write([]byte{byte(canonicalTagSlice)})
+		writeCanonicalUvarint(hasher, uint64(rv.Len()))
+
+		for i := range rv.Len() {
+			if err := walkCanonical(rv.Index(i).Interface(), hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("slice: %w", err)
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		hasher.Write([]byte{byte(canonicalTagMap)})
+		writeCanonicalUvarint(hasher, uint64(rv.Len()))
+
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return compareMapKeys(keys[i], keys[j]) < 0
+		})
+
+		for _, key := range keys {
+			if err := walkCanonical(key.Interface(), hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			if err := walkCanonical(rv.MapIndex(key).Interface(), hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("map value: %w", err)
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+		rt := rv.Type()
+
+		identity := rt.String()
+		if opts.TypeName != nil {
+			identity = opts.TypeName(rt)
+		}
+
+		hasher.Write([]byte{byte(canonicalTagStruct)})
+		writeCanonicalBytes(hasher, []byte(identity))
+
+		exported := make([]int, 0, rv.NumField())
+		for i := range rv.NumField() {
+			if rv.Field(i).CanInterface() {
+				exported = append(exported, i)
+			}
+		}
+		writeCanonicalUvarint(hasher, uint64(len(exported)))
+
+		for _, i := range exported {
+			if err := walkCanonical(rv.Field(i).Interface(), hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("struct: %w", err)
+			}
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// writeCanonicalBytes writes b length-prefixed with a uvarint.
+func writeCanonicalBytes(hasher hash.Hash, b []byte) {
+	writeCanonicalUvarint(hasher, uint64(len(b)))
+	hasher.Write(b)
+}
+
+// writeCanonicalUvarint writes n as a uvarint.
+func writeCanonicalUvarint(hasher hash.Hash, n uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	size := binary.PutUvarint(buf[:], n)
+	hasher.Write(buf[:size])
+}
@@ -0,0 +1,37 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+// selfPointerType is its own pointer element type, the same shape a
+// self-referential linked list reduces to once every field is stripped away:
+// a value that is a pointer to itself.
+type selfPointerType *selfPointerType
+
+// TestFromCanonical_SelfReferentialPointerDoesNotHang ensures the pointer/
+// interface dereference loop in walkCanonical is bounded the same way the
+// equivalent loop in walkObject is, instead of looping forever.
+func TestFromCanonical_SelfReferentialPointerDoesNotHang(t *testing.T) {
+	done := make(chan error, 1)
+
+	go func() {
+		var n selfPointerType
+		n = &n
+
+		_, err := gohash.FromCanonical(n, sha256.New(), nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.Error(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("FromCanonical hung on a self-referential pointer chain")
+	}
+}
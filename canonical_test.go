@@ -0,0 +1,30 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+// TestFromCanonical_CustomHashHook ensures that FromCanonical honors the
+// BinaryMarshaler hook for types with no exported fields (e.g. time.Time),
+// instead of collapsing every value of the type to a single hash.
+func TestFromCanonical_CustomHashHook(t *testing.T) {
+	t1, err := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	t2, err := time.Parse(time.RFC3339, "2025-06-15T00:00:00Z")
+	require.NoError(t, err)
+
+	h1, err := gohash.FromCanonical(t1, sha256.New(), nil)
+	require.NoError(t, err)
+
+	h2, err := gohash.FromCanonical(t2, sha256.New(), nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, h1, h2)
+}
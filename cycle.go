@@ -0,0 +1,52 @@
+package gohash
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// backReferenceTag marks a cycle back-reference in the output of [walkObject]
+// and [walkCanonical]. It is chosen outside the range of [reflect.Kind] values
+// used as type tags elsewhere so it cannot collide with them.
+const backReferenceTag = 0xFF
+
+// visitFrame identifies one pointer-like node on the current walk path: its
+// address and static type.
+type visitFrame struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+// visitStack is an explicit stack of the pointer/map/slice/interface nodes on
+// the path from the root value down to the node currently being walked.
+//
+// A flat "seen" set conflates a real cycle with two independent occurrences
+// of the same address, for example []*T{p, p}: the second p is not part of a
+// cycle, it is simply visited again from a different position, and should
+// still contribute its full content. A stack tells these apart because a
+// cycle can only be the same (ptr, typ) reappearing on the *current* path,
+// not anywhere in the value.
+type visitStack struct {
+	frames []visitFrame
+}
+
+// push records entry into a pointer-like node. If (ptr, typ) is already on
+// the stack, this is a genuine cycle: push returns ok=false and the distance
+// from the top of the stack to the earlier occurrence, and the caller must
+// not push or recurse further. Otherwise the frame is pushed and ok=true; the
+// caller must call pop once it is done walking the node.
+func (s *visitStack) push(ptr unsafe.Pointer, typ reflect.Type) (depth int, ok bool) {
+	for i := len(s.frames) - 1; i >= 0; i-- {
+		if s.frames[i].ptr == ptr && s.frames[i].typ == typ {
+			return len(s.frames) - i, false
+		}
+	}
+
+	s.frames = append(s.frames, visitFrame{ptr: ptr, typ: typ})
+	return 0, true
+}
+
+// pop removes the frame most recently pushed.
+func (s *visitStack) pop() {
+	s.frames = s.frames[:len(s.frames)-1]
+}
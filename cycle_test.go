@@ -0,0 +1,48 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+type cycleNode struct {
+	Value int
+	Next  *cycleNode
+}
+
+// TestFrom_Cycle ensures a genuine pointer cycle is hashed via a
+// back-reference instead of recursing forever.
+func TestFrom_Cycle(t *testing.T) {
+	a := &cycleNode{Value: 1}
+	a.Next = a
+
+	h1, err := gohash.From(a, sha256.New())
+	require.NoError(t, err)
+
+	b := &cycleNode{Value: 1}
+	b.Next = b
+	h2, err := gohash.From(b, sha256.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, h1, h2)
+}
+
+// TestFrom_SharedPointerIsNotACycle ensures that two independent occurrences
+// of the same pointer in a slice are not conflated with a cycle: []*T{p, p}
+// must still hash both elements' full content, not silently skip the second
+// as "already visited".
+func TestFrom_SharedPointerIsNotACycle(t *testing.T) {
+	shared := &cycleNode{Value: 42}
+
+	sameTwice, err := gohash.From([]*cycleNode{shared, shared}, sha256.New())
+	require.NoError(t, err)
+
+	oneNil, err := gohash.From([]*cycleNode{shared, nil}, sha256.New())
+	require.NoError(t, err)
+
+	assert.NotEqual(t, sameTwice, oneNil)
+}
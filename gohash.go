@@ -2,6 +2,7 @@
 package gohash
 
 import (
+	"encoding"
 	"encoding/binary"
 	"fmt"
 	"hash"
@@ -88,8 +89,40 @@ func compareMapKeys(a, b reflect.Value) int {
 	}
 }
 
+// writeCustomHash checks whether v opts into describing its own canonical
+// representation via [interface{ HashAppend(b []byte) []byte }] or
+// [encoding.BinaryMarshaler], and if so writes that representation, prefixed
+// with v's type name to avoid collisions across types. ok reports whether v
+// matched either hook.
+func writeCustomHash(v any, hasher hash.Hash) (ok bool, err error) {
+	if v == nil {
+		return false, nil
+	}
+
+	if custom, match := v.(interface{ HashAppend(b []byte) []byte }); match {
+		hasher.Write([]byte(reflect.TypeOf(v).String()))
+		hasher.Write(custom.HashAppend(nil))
+		return true, nil
+	}
+
+	if marshaler, match := v.(encoding.BinaryMarshaler); match {
+		data, err := marshaler.MarshalBinary()
+		if err != nil {
+			return true, fmt.Errorf("binarymarshaler: %w", err)
+		}
+
+		hasher.Write([]byte(reflect.TypeOf(v).String()))
+		hasher.Write(data)
+		return true, nil
+	}
+
+	return false, nil
+}
+
 // walkObject recursively iterates over structs, maps and arrays and adds values into hasher.
-func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool) error {
+// opts is nil when called from [From], which keeps that entry point free of any
+// option- or tag-driven behavior; [FromWithOptions] always passes a resolved, non-nil *Options.
+func walkObject(input any, hasher hash.Hash, depth int, visited *visitStack, opts *Options) error {
 	if depth > 100 {
 		return fmt.Errorf("depth exceeded for type %T", input)
 	}
@@ -102,6 +135,11 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 	if kind == reflect.Ptr || kind == reflect.Map ||
 		kind == reflect.Slice || kind == reflect.Interface {
 		if rv.IsNil() {
+			if kind == reflect.Ptr && opts != nil && opts.ZeroNil {
+				zero := reflect.New(rv.Type().Elem()).Elem().Interface()
+				return walkObject(zero, hasher, depth+1, visited, opts)
+			}
+
 			// Write type information for nil pointers to distinguish nil *int from nil *string
 			if kind == reflect.Ptr {
 				hasher.Write([]byte(rv.Type().String()))
@@ -109,12 +147,25 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 			return nil
 		}
 
-		// Skip if we already visited that node
-		ptr := rv.Pointer()
-		if visited[ptr] {
+		// A back-reference, not a plain "already seen" skip: this distinguishes a
+		// genuine cycle from two independent occurrences of the same address.
+		backDepth, ok := visited.push(rv.UnsafePointer(), rv.Type())
+		if !ok {
+			hasher.Write([]byte{backReferenceTag})
+			if err := binary.Write(hasher, binary.LittleEndian, uint64(backDepth)); err != nil {
+				return fmt.Errorf("writer: %w", err)
+			}
 			return nil
 		}
-		visited[ptr] = true
+		defer visited.pop()
+	}
+
+	// Give values a chance to describe their own canonical identity before we
+	// dereference pointers, so that pointer-receiver implementations are
+	// honored. This lets types such as time.Time or big.Int hash by their
+	// semantic value instead of their private field layout.
+	if ok, err := writeCustomHash(input, hasher); ok || err != nil {
+		return err
 	}
 
 	// [reflect.Kind] is actually a uint, therefore we can use it directly
@@ -152,6 +203,15 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 		break
 	}
 
+	// The value may only have revealed itself to implement a custom hashing
+	// hook once indirection through a pointer or interface was peeled off
+	// above, for example a *any holding a time.Time.
+	if rv.IsValid() {
+		if ok, err := writeCustomHash(rv.Interface(), hasher); ok || err != nil {
+			return err
+		}
+	}
+
 	vt[0] = byte(kind)
 
 	// Process value based on it's type
@@ -232,7 +292,7 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 		hasher.Write(vt[:1])
 
 		for i := range rv.Len() {
-			if err := walkObject(rv.Index(i).Interface(), hasher, depth+1, visited); err != nil {
+			if err := walkObject(rv.Index(i).Interface(), hasher, depth+1, visited, opts); err != nil {
 				return fmt.Errorf("slice: %w", err)
 			}
 		}
@@ -267,12 +327,12 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 		})
 
 		for _, key := range keys {
-			if err := walkObject(key.Interface(), hasher, depth+1, visited); err != nil {
+			if err := walkObject(key.Interface(), hasher, depth+1, visited, opts); err != nil {
 				return fmt.Errorf("map value: %w", err)
 			}
 			value := rv.MapIndex(key)
 
-			if err := walkObject(value.Interface(), hasher, depth+1, visited); err != nil {
+			if err := walkObject(value.Interface(), hasher, depth+1, visited, opts); err != nil {
 				return fmt.Errorf("map value: %w", err)
 			}
 		}
@@ -290,6 +350,10 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 		hasher.Write([]byte(rt.String()))
 		hasher.Write([]byte(rt.PkgPath()))
 
+		if opts != nil {
+			return walkStructWithOptions(rt, rv, hasher, depth, visited, opts)
+		}
+
 		nFields := rv.NumField()
 
 		// If empty, then do not record any data.
@@ -313,7 +377,7 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 				continue
 			}
 
-			if err := walkObject(field.Interface(), hasher, depth+1, visited); err != nil {
+			if err := walkObject(field.Interface(), hasher, depth+1, visited, opts); err != nil {
 				return fmt.Errorf("struct: %w", err)
 			}
 		}
@@ -327,9 +391,9 @@ func walkObject(input any, hasher hash.Hash, depth int, visited map[uintptr]bool
 // From accepts any golang value including pointers and recursively converts
 // then to a unique hash value.
 func From(input any, hasher hash.Hash) (Hash, error) {
-	visited := make(map[uintptr]bool)
+	visited := &visitStack{}
 
-	if err := walkObject(input, hasher, 0, visited); err != nil {
+	if err := walkObject(input, hasher, 0, visited, nil); err != nil {
 		return nil, fmt.Errorf("from: %w", err)
 	}
 
@@ -0,0 +1,51 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+// hashAppendType opts into HashAppend so it can be hashed by semantic value
+// instead of its private field layout.
+type hashAppendType struct {
+	value string
+}
+
+func (h hashAppendType) HashAppend(b []byte) []byte {
+	return append(b, h.value...)
+}
+
+// TestFrom_HashAppendHook ensures a type implementing HashAppend is hashed by
+// that method's output rather than by recursing into its fields.
+func TestFrom_HashAppendHook(t *testing.T) {
+	h1, err := gohash.From(hashAppendType{value: "a"}, sha256.New())
+	require.NoError(t, err)
+
+	h2, err := gohash.From(hashAppendType{value: "b"}, sha256.New())
+	require.NoError(t, err)
+
+	assert.NotEqual(t, h1, h2)
+}
+
+// TestFrom_BinaryMarshalerHook ensures time.Time, which has no exported
+// fields, still distinguishes different instants via its BinaryMarshaler.
+func TestFrom_BinaryMarshalerHook(t *testing.T) {
+	t1, err := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	t2, err := time.Parse(time.RFC3339, "2025-06-15T00:00:00Z")
+	require.NoError(t, err)
+
+	h1, err := gohash.From(t1, sha256.New())
+	require.NoError(t, err)
+
+	h2, err := gohash.From(t2, sha256.New())
+	require.NoError(t, err)
+
+	assert.NotEqual(t, h1, h2)
+}
@@ -0,0 +1,219 @@
+package gohash
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Options controls the optional, tag-aware behavior of [FromWithOptions]. The
+// zero value is valid and matches the defaults documented on each field.
+type Options struct {
+	// ZeroNil treats nil pointers as the zero value of the type they point
+	// to, instead of hashing only the pointer's type name.
+	ZeroNil bool
+
+	// IncludeUnexported reaches unexported struct fields using unsafe,
+	// mirroring the behavior of [reflect.DeepEqual]. A struct that is not
+	// addressable (for example because it was obtained from a map or passed
+	// by value through an interface) is copied first, so its unexported
+	// fields are hashed as well.
+	IncludeUnexported bool
+
+	// TagName is the struct tag key inspected for per-field directives.
+	// Defaults to "hash".
+	TagName string
+}
+
+// resolveOptions fills in defaults for a possibly-nil *Options, returning a
+// new value that is always safe to read from.
+func resolveOptions(opts *Options) *Options {
+	resolved := Options{TagName: "hash"}
+	if opts != nil {
+		resolved = *opts
+		if resolved.TagName == "" {
+			resolved.TagName = "hash"
+		}
+	}
+	return &resolved
+}
+
+// FromWithOptions behaves like [From], but additionally honors `hash:"..."`
+// struct tags and the behaviors configured by opts. A nil opts is equivalent
+// to &Options{}.
+//
+// Recognized tag directives, comma-separated within a single `hash:"..."` tag:
+//
+//   - "-"         skip the field entirely
+//   - "name=Foo"  contribute "Foo" as the field's identity instead of its Go name
+//   - "string"    hash fmt.Sprintf("%v", field) instead of recursing into it
+//   - "set"       treat a slice/array field as an unordered set: elements are
+//     hashed independently and combined in sorted order, so element order
+//     does not affect the result
+func FromWithOptions(input any, hasher hash.Hash, opts *Options) (Hash, error) {
+	resolved := resolveOptions(opts)
+	visited := &visitStack{}
+
+	if err := walkObject(input, hasher, 0, visited, resolved); err != nil {
+		return nil, fmt.Errorf("fromwithoptions: %w", err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// fieldPlan is the parsed hash tag plus identity for one struct field,
+// computed once per (type, tag name) and cached in fieldPlanCache.
+type fieldPlan struct {
+	index    int
+	name     string
+	skip     bool
+	asString bool
+	asSet    bool
+}
+
+type fieldPlanKey struct {
+	t       reflect.Type
+	tagName string
+}
+
+var fieldPlanCache sync.Map // map[fieldPlanKey][]fieldPlan
+
+// fieldPlansFor returns the cached field plans for t, parsing and caching
+// them on first use.
+func fieldPlansFor(t reflect.Type, tagName string) []fieldPlan {
+	key := fieldPlanKey{t: t, tagName: tagName}
+	if cached, ok := fieldPlanCache.Load(key); ok {
+		return cached.([]fieldPlan)
+	}
+
+	plans := make([]fieldPlan, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, skip, asString, asSet := parseFieldTag(field.Tag.Get(tagName))
+		if name == "" {
+			name = field.Name
+		}
+
+		plans[i] = fieldPlan{index: i, name: name, skip: skip, asString: asString, asSet: asSet}
+	}
+
+	actual, _ := fieldPlanCache.LoadOrStore(key, plans)
+	return actual.([]fieldPlan)
+}
+
+// parseFieldTag parses the comma-separated directives in a single hash tag.
+func parseFieldTag(raw string) (name string, skip, asString, asSet bool) {
+	if raw == "-" {
+		return "", true, false, false
+	}
+
+	for _, part := range strings.Split(raw, ",") {
+		switch {
+		case part == "string":
+			asString = true
+		case part == "set":
+			asSet = true
+		case strings.HasPrefix(part, "name="):
+			name = strings.TrimPrefix(part, "name=")
+		}
+	}
+
+	return name, skip, asString, asSet
+}
+
+// walkStructWithOptions hashes a struct's fields according to their parsed
+// tags. Called once the struct's type identity has already been written.
+func walkStructWithOptions(rt reflect.Type, rv reflect.Value, hasher hash.Hash, depth int, visited *visitStack, opts *Options) error {
+	plans := fieldPlansFor(rt, opts.TagName)
+
+	// rv is rarely addressable here, since it usually arrives as the .Elem()
+	// of an any passed by value to walkObject. Reaching unexported fields via
+	// unsafe requires an addressable value, so make an addressable copy up
+	// front instead of silently skipping every unexported field, mirroring
+	// the copy-if-needed technique in pod.go's podAddr.
+	if opts.IncludeUnexported && !rv.CanAddr() {
+		owned := reflect.New(rv.Type()).Elem()
+		owned.Set(rv)
+		rv = owned
+	}
+
+	hasher.Write([]byte{byte(reflect.Struct)})
+
+	for _, plan := range plans {
+		if plan.skip {
+			continue
+		}
+
+		field := rv.Field(plan.index)
+		if !field.CanInterface() {
+			if !opts.IncludeUnexported || !field.CanAddr() {
+				continue
+			}
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		}
+
+		hasher.Write([]byte(plan.name))
+
+		switch {
+		case plan.asString:
+			hasher.Write([]byte(fmt.Sprintf("%v", field.Interface())))
+
+		case plan.asSet && (field.Kind() == reflect.Slice || field.Kind() == reflect.Array):
+			if err := writeAsSet(field, hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("struct field %q: %w", plan.name, err)
+			}
+
+		default:
+			if err := walkObject(field.Interface(), hasher, depth+1, visited, opts); err != nil {
+				return fmt.Errorf("struct field %q: %w", plan.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeAsSet hashes a slice/array field as an unordered set: every element is
+// rendered independently, the renderings are sorted, and the sorted bytes are
+// then written into hasher, so the original element order does not affect
+// the result.
+func writeAsSet(field reflect.Value, hasher hash.Hash, depth int, visited *visitStack, opts *Options) error {
+	n := field.Len()
+	rendered := make([][]byte, n)
+
+	for i := range n {
+		buf := &byteBuffer{}
+		if err := walkObject(field.Index(i).Interface(), buf, depth, visited, opts); err != nil {
+			return fmt.Errorf("set element %d: %w", i, err)
+		}
+		rendered[i] = buf.buf
+	}
+
+	sort.Slice(rendered, func(i, j int) bool {
+		return bytes.Compare(rendered[i], rendered[j]) < 0
+	})
+
+	for _, r := range rendered {
+		hasher.Write(r)
+	}
+
+	return nil
+}
+
+// byteBuffer is a minimal [hash.Hash] that accumulates written bytes
+// verbatim. It lets [writeAsSet] render a value into a comparable byte
+// string without needing a second instance of the caller's real hasher.
+type byteBuffer struct {
+	buf []byte
+}
+
+func (b *byteBuffer) Write(p []byte) (int, error) { b.buf = append(b.buf, p...); return len(p), nil }
+func (b *byteBuffer) Sum(p []byte) []byte         { return append(p, b.buf...) }
+func (b *byteBuffer) Reset()                      { b.buf = b.buf[:0] }
+func (b *byteBuffer) Size() int                   { return len(b.buf) }
+func (b *byteBuffer) BlockSize() int              { return 1 }
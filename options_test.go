@@ -0,0 +1,85 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+type taggedStruct struct {
+	Ignored  string   `hash:"-"`
+	Renamed  int      `hash:"name=Count"`
+	AsString float64  `hash:"string"`
+	Tags     []string `hash:"set"`
+}
+
+// TestFromWithOptions_Tags exercises the `hash:"..."` directives: a "-" field
+// must not affect the hash, a "set" field must ignore element order, and a
+// field renamed with "name=" must still contribute its value.
+func TestFromWithOptions_Tags(t *testing.T) {
+	base := taggedStruct{Ignored: "a", Renamed: 1, AsString: 3.14, Tags: []string{"x", "y"}}
+
+	h1, err := gohash.FromWithOptions(base, sha256.New(), nil)
+	require.NoError(t, err)
+
+	ignoredChanged := base
+	ignoredChanged.Ignored = "b"
+	h2, err := gohash.FromWithOptions(ignoredChanged, sha256.New(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2, "hash:\"-\" field must not affect the hash")
+
+	reordered := base
+	reordered.Tags = []string{"y", "x"}
+	h3, err := gohash.FromWithOptions(reordered, sha256.New(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h3, "hash:\"set\" field must ignore element order")
+
+	renamedChanged := base
+	renamedChanged.Renamed = 2
+	h4, err := gohash.FromWithOptions(renamedChanged, sha256.New(), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h4)
+}
+
+type unexportedFieldStruct struct {
+	Exported int
+	private  int
+}
+
+// TestFromWithOptions_IncludeUnexported ensures unexported fields are folded
+// into the hash only when Options.IncludeUnexported is set.
+func TestFromWithOptions_IncludeUnexported(t *testing.T) {
+	a := unexportedFieldStruct{Exported: 1, private: 1}
+	b := unexportedFieldStruct{Exported: 1, private: 2}
+
+	h1, err := gohash.FromWithOptions(a, sha256.New(), nil)
+	require.NoError(t, err)
+	h2, err := gohash.FromWithOptions(b, sha256.New(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2, "unexported fields must be ignored by default")
+
+	opts := &gohash.Options{IncludeUnexported: true}
+	h3, err := gohash.FromWithOptions(a, sha256.New(), opts)
+	require.NoError(t, err)
+	h4, err := gohash.FromWithOptions(b, sha256.New(), opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, h3, h4, "unexported fields must be hashed when IncludeUnexported is set")
+}
+
+// TestFromWithOptions_ZeroNil ensures a nil pointer is treated as its
+// element's zero value when ZeroNil is set.
+func TestFromWithOptions_ZeroNil(t *testing.T) {
+	var nilPtr *int
+	zero := 0
+
+	opts := &gohash.Options{ZeroNil: true}
+
+	h1, err := gohash.FromWithOptions(nilPtr, sha256.New(), opts)
+	require.NoError(t, err)
+	h2, err := gohash.FromWithOptions(&zero, sha256.New(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+}
@@ -0,0 +1,89 @@
+package gohash
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// podInfo records whether a type can be hashed by copying its raw memory
+// representation, and how many bytes that representation occupies.
+type podInfo struct {
+	isPOD bool
+	size  uintptr
+}
+
+// podCache holds the result of [podInfoFor], computed once per [reflect.Type].
+var podCache sync.Map // map[reflect.Type]podInfo
+
+// podInfoFor reports whether t is "hashable as memory": a value whose bytes,
+// taken as-is, fully and uniquely determine its hash with no risk of two
+// distinct values sharing a representation. This excludes any type that
+// carries indirection or out-of-line length (pointers, slices, maps,
+// interfaces, strings, chans, funcs, unsafe.Pointer) anywhere in its layout,
+// since copying those bytes would copy an address or descriptor rather than
+// the data it refers to.
+func podInfoFor(t reflect.Type) podInfo {
+	if cached, ok := podCache.Load(t); ok {
+		return cached.(podInfo)
+	}
+
+	info := podInfo{isPOD: isPOD(t), size: t.Size()}
+
+	actual, _ := podCache.LoadOrStore(t, info)
+	return actual.(podInfo)
+}
+
+// isPOD recursively determines whether t's in-memory representation is
+// self-contained.
+func isPOD(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+
+	case reflect.Array:
+		return isPOD(t.Elem())
+
+	case reflect.Struct:
+		for i := range t.NumField() {
+			field := t.Field(i)
+
+			// An unexported field's bytes would be copied into the hash
+			// verbatim by the memory fast path, unlike the reflective
+			// struct program in typed.go's buildKindProgram, which silently
+			// skips fields that !field.IsExported(). Bail out of POD classification so such
+			// structs fall back to that field-by-field path instead of
+			// leaking private state into the hash.
+			if !field.IsExported() {
+				return false
+			}
+
+			if !isPOD(field.Type) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		// Pointers, slices, maps, interfaces, strings, chans, funcs and
+		// unsafe.Pointer all carry indirection or metadata a flat memory
+		// copy would miss.
+		return false
+	}
+}
+
+// podAddr returns a pointer to rv's underlying memory, copying rv into an
+// addressable location first if necessary. Used to build the byte slice
+// passed to hasher.Write for values whose type was proven POD by [isPOD].
+func podAddr(rv reflect.Value) unsafe.Pointer {
+	if !rv.CanAddr() {
+		owned := reflect.New(rv.Type()).Elem()
+		owned.Set(rv)
+		rv = owned
+	}
+
+	return rv.Addr().UnsafePointer()
+}
@@ -0,0 +1,30 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+type podWithUnexported struct {
+	A int64
+	b int64
+}
+
+// TestHasherForType_PODIgnoresUnexportedFields ensures that the memory fast
+// path for POD structs does not leak unexported field bytes into the hash,
+// matching the rest of the package, which silently ignores private fields.
+func TestHasherForType_PODIgnoresUnexportedFields(t *testing.T) {
+	hasher := gohash.HasherForType[podWithUnexported](sha256.New)
+
+	h1, err := hasher(podWithUnexported{A: 1, b: 2})
+	require.NoError(t, err)
+
+	h2, err := hasher(podWithUnexported{A: 1, b: 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, h1, h2)
+}
@@ -0,0 +1,309 @@
+package gohash
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// typeProgram is a compiled, kind-specific hashing routine for a single
+// [reflect.Type]. It is built once per type and reused on every call,
+// avoiding the reflect.Kind switch that [walkObject] re-does on every value.
+type typeProgram struct {
+	write func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error
+}
+
+// structFieldProgram is a precompiled hasher for a single exported struct field.
+type structFieldProgram struct {
+	index   int
+	program *typeProgram
+}
+
+// programCache holds compiled [typeProgram] values keyed by [reflect.Type].
+var programCache sync.Map // map[reflect.Type]*typeProgram
+
+// programCompileMu serializes compilation, so that no goroutine can pick up a
+// placeholder from programCache before its write field has been assigned.
+var programCompileMu sync.Mutex
+
+// hasherState is pooled per-call scratch space for [HasherForType], so that
+// encoding a scalar leaf does not need to allocate a fresh buffer on every
+// invocation.
+type hasherState struct {
+	scratch [128]byte
+}
+
+var hasherStatePool = sync.Pool{New: func() any { return new(hasherState) }}
+
+var (
+	hashAppendHookType  = reflect.TypeFor[interface{ HashAppend(b []byte) []byte }]()
+	binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+)
+
+// HasherForType returns a specialized hashing function for T. On first use for
+// a given type, it walks the type once via reflection and compiles a closure
+// that hashes values of that type without re-inspecting the type on every
+// call. The compiled program is cached, so repeated calls against the same T
+// only pay the reflection cost once per process, which makes this a good fit
+// for hot paths such as caching request keys.
+//
+// The returned function is safe for concurrent use.
+func HasherForType[T any](hasher func() hash.Hash) func(T) (Hash, error) {
+	t := reflect.TypeFor[T]()
+
+	programCompileMu.Lock()
+	program := compileProgram(t)
+	programCompileMu.Unlock()
+
+	return func(v T) (Hash, error) {
+		h := hasher()
+
+		state := hasherStatePool.Get().(*hasherState)
+		scratch := state.scratch[:0]
+
+		err := program.write(reflect.ValueOf(v), h, &scratch, &visitStack{})
+		hasherStatePool.Put(state)
+
+		if err != nil {
+			return nil, fmt.Errorf("hasherfortype: %w", err)
+		}
+
+		return h.Sum(nil), nil
+	}
+}
+
+// compileProgram returns the cached [typeProgram] for t, compiling and
+// storing one if this is the first time t has been seen.
+//
+// A placeholder *typeProgram is stored in programCache before t is walked, so
+// that a type which refers to itself (directly, or through a pointer/slice/
+// map, e.g. a linked-list node) finds that placeholder already in the cache
+// instead of recursing into buildProgram again. Every generated closure calls
+// through the *typeProgram it captured rather than embedding a function
+// value, so the closures built for the self-reference see the real
+// implementation once the placeholder's write field is assigned below.
+func compileProgram(t reflect.Type) *typeProgram {
+	if cached, ok := programCache.Load(t); ok {
+		return cached.(*typeProgram)
+	}
+
+	placeholder := &typeProgram{}
+	actual, loaded := programCache.LoadOrStore(t, placeholder)
+	program := actual.(*typeProgram)
+	if loaded {
+		return program
+	}
+
+	program.write = buildProgram(t).write
+	return program
+}
+
+// buildProgram walks t once and compiles a [typeProgram] for it.
+func buildProgram(t reflect.Type) *typeProgram {
+	// Give the type a chance to describe its own canonical identity before
+	// falling into the generic kind switch, mirroring the order [walkObject]
+	// checks HashAppend/BinaryMarshaler in before recursing into fields. This
+	// is decided once per type rather than per value, since interface
+	// satisfaction doesn't change between calls.
+	if t.Implements(hashAppendHookType) || t.Implements(binaryMarshalerType) {
+		generic := buildKindProgram(t)
+		isPointer := t.Kind() == reflect.Pointer
+
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			// A nil pointer must not reach the hook: calling a value-receiver
+			// method promoted onto *T would dereference it and panic. Let the
+			// generic program's own nil handling take over instead.
+			if isPointer && rv.IsNil() {
+				return generic.write(rv, hasher, scratch, visited)
+			}
+
+			if ok, err := writeCustomHash(rv.Interface(), hasher); ok || err != nil {
+				return err
+			}
+			return generic.write(rv, hasher, scratch, visited)
+		}}
+	}
+
+	return buildKindProgram(t)
+}
+
+// buildKindProgram compiles the generic, reflect.Kind-driven [typeProgram]
+// for t, with no custom-hash-hook check.
+func buildKindProgram(t reflect.Type) *typeProgram {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], uint64(rv.Int()))
+			hasher.Write(*scratch)
+			return nil
+		}}
+
+	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], rv.Uint())
+			hasher.Write(*scratch)
+			return nil
+		}}
+
+	case reflect.Float32, reflect.Float64:
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], math.Float64bits(rv.Float()))
+			hasher.Write(*scratch)
+			return nil
+		}}
+
+	case reflect.Bool:
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			*scratch = (*scratch)[:0]
+			if rv.Bool() {
+				*scratch = append(*scratch, 1)
+			} else {
+				*scratch = append(*scratch, 0)
+			}
+			hasher.Write(*scratch)
+			return nil
+		}}
+
+	case reflect.String:
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			hasher.Write([]byte(rv.String()))
+			return nil
+		}}
+
+	case reflect.Pointer:
+		elemProgram := compileProgram(t.Elem())
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			if rv.IsNil() {
+				hasher.Write([]byte(t.String()))
+				return nil
+			}
+
+			// A back-reference, not a plain "already seen" skip: this
+			// distinguishes a genuine cycle (e.g. a circular linked list)
+			// from two independent occurrences of the same address.
+			backDepth, ok := visited.push(rv.UnsafePointer(), t)
+			if !ok {
+				hasher.Write([]byte{backReferenceTag})
+				*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], uint64(backDepth))
+				hasher.Write(*scratch)
+				return nil
+			}
+			defer visited.pop()
+
+			return elemProgram.write(rv.Elem(), hasher, scratch, visited)
+		}}
+
+	case reflect.Array, reflect.Slice:
+		elemProgram := compileProgram(t.Elem())
+		trackCycles := t.Kind() == reflect.Slice
+
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			n := rv.Len()
+			if n == 0 {
+				hasher.Write([]byte(t.String()))
+				return nil
+			}
+
+			if trackCycles {
+				backDepth, ok := visited.push(rv.UnsafePointer(), t)
+				if !ok {
+					hasher.Write([]byte{backReferenceTag})
+					*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], uint64(backDepth))
+					hasher.Write(*scratch)
+					return nil
+				}
+				defer visited.pop()
+			}
+
+			for i := range n {
+				if err := elemProgram.write(rv.Index(i), hasher, scratch, visited); err != nil {
+					return fmt.Errorf("slice: %w", err)
+				}
+			}
+			return nil
+		}}
+
+	case reflect.Map:
+		keyProgram := compileProgram(t.Key())
+		valProgram := compileProgram(t.Elem())
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			if rv.Len() == 0 {
+				hasher.Write([]byte(t.String()))
+				return nil
+			}
+
+			backDepth, ok := visited.push(rv.UnsafePointer(), t)
+			if !ok {
+				hasher.Write([]byte{backReferenceTag})
+				*scratch = binary.LittleEndian.AppendUint64((*scratch)[:0], uint64(backDepth))
+				hasher.Write(*scratch)
+				return nil
+			}
+			defer visited.pop()
+
+			keys := rv.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return compareMapKeys(keys[i], keys[j]) < 0
+			})
+
+			for _, key := range keys {
+				if err := keyProgram.write(key, hasher, scratch, visited); err != nil {
+					return fmt.Errorf("map key: %w", err)
+				}
+				if err := valProgram.write(rv.MapIndex(key), hasher, scratch, visited); err != nil {
+					return fmt.Errorf("map value: %w", err)
+				}
+			}
+			return nil
+		}}
+
+	case reflect.Struct:
+		// A POD struct's bytes fully determine its value, so it can be hashed
+		// with a single memory copy instead of one reflect call per field.
+		if info := podInfoFor(t); info.isPOD {
+			typeTag := []byte(t.String() + t.PkgPath())
+			size := info.size
+
+			return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+				hasher.Write(typeTag)
+				hasher.Write(unsafe.Slice((*byte)(podAddr(rv)), size))
+				return nil
+			}}
+		}
+
+		fields := make([]structFieldProgram, 0, t.NumField())
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fields = append(fields, structFieldProgram{index: i, program: compileProgram(field.Type)})
+		}
+
+		typeTag := []byte(t.String() + t.PkgPath())
+
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			hasher.Write(typeTag)
+
+			for _, field := range fields {
+				if err := field.program.write(rv.Field(field.index), hasher, scratch, visited); err != nil {
+					return fmt.Errorf("struct field %d: %w", field.index, err)
+				}
+			}
+			return nil
+		}}
+
+	default:
+		// Fall back to the general reflective walker for kinds that are rarely
+		// hot (interfaces, channels, funcs, complex numbers, ...).
+		return &typeProgram{write: func(rv reflect.Value, hasher hash.Hash, scratch *[]byte, visited *visitStack) error {
+			return walkObject(rv.Interface(), hasher, 0, &visitStack{}, nil)
+		}}
+	}
+}
@@ -0,0 +1,27 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+// TestHasherForType_NilPointerToHookType ensures a nil *T, where T implements
+// a custom hash hook via a value receiver, does not panic by dereferencing
+// the nil pointer to call the hook.
+func TestHasherForType_NilPointerToHookType(t *testing.T) {
+	hasher := gohash.HasherForType[*time.Time](sha256.New)
+
+	var nilTime *time.Time
+	h1, err := hasher(nilTime)
+	require.NoError(t, err)
+
+	h2, err := hasher(nilTime)
+	require.NoError(t, err)
+
+	assert.Equal(t, h1, h2)
+}
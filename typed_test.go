@@ -0,0 +1,58 @@
+package gohash_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vladmiller/gohash"
+)
+
+// selfRefNode is a linked-list node: its own type contains a pointer back to
+// itself, which is the shape [gohash.HasherForType] must be able to compile
+// without recursing into itself forever.
+type selfRefNode struct {
+	Value int
+	Next  *selfRefNode
+}
+
+// TestHasherForType_SelfReferentialType ensures that compiling a program for
+// a self-referential type (e.g. a linked-list node) terminates instead of
+// recursing into buildProgram forever.
+func TestHasherForType_SelfReferentialType(t *testing.T) {
+	hasher := gohash.HasherForType[*selfRefNode](sha256.New)
+
+	list := &selfRefNode{Value: 1, Next: &selfRefNode{Value: 2, Next: &selfRefNode{Value: 3}}}
+
+	h1, err := hasher(list)
+	require.NoError(t, err)
+
+	h2, err := hasher(&selfRefNode{Value: 1, Next: &selfRefNode{Value: 2, Next: &selfRefNode{Value: 3}}})
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	h3, err := hasher(&selfRefNode{Value: 1, Next: &selfRefNode{Value: 2, Next: &selfRefNode{Value: 4}}})
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+}
+
+// TestHasherForType_Cycle ensures that an actual runtime cycle (not just a
+// self-referential type) is hashed via a back-reference instead of hanging or
+// overflowing the stack.
+func TestHasherForType_Cycle(t *testing.T) {
+	hasher := gohash.HasherForType[*selfRefNode](sha256.New)
+
+	cyclic := &selfRefNode{Value: 1}
+	cyclic.Next = cyclic
+
+	h1, err := hasher(cyclic)
+	require.NoError(t, err)
+
+	otherCyclic := &selfRefNode{Value: 1}
+	otherCyclic.Next = otherCyclic
+	h2, err := hasher(otherCyclic)
+	require.NoError(t, err)
+
+	assert.Equal(t, h1, h2)
+}